@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"time"
+)
+
+// pngSignature is the 8-byte marker that starts every PNG stream.
+var pngSignature = [8]byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+// encodeAPNG writes frames as an APNG stream: a regular PNG with the
+// acTL, fcTL and fdAT chunks defined by the Animated Portable Network
+// Graphics spec spliced in around the first frame's IDAT chunks. Each
+// frame is first encoded independently with image/png so its IDAT
+// payload can be reused as-is (for the first frame) or relabeled as
+// fdAT (for the rest).
+func encodeAPNG(w io.Writer, frames []*image.RGBA, delay time.Duration) error {
+	delayNum, delayDen := delayFraction(delay)
+
+	first, err := splitPNG(frames[0])
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(pngSignature[:]); err != nil {
+		return err
+	}
+	if err := writeChunk(w, "IHDR", first.ihdr); err != nil {
+		return err
+	}
+	if err := writeChunk(w, "acTL", acTL(len(frames))); err != nil {
+		return err
+	}
+
+	var seq uint32
+	if err := writeChunk(w, "fcTL", fcTL(seq, frames[0].Bounds(), delayNum, delayDen)); err != nil {
+		return err
+	}
+	seq++
+	for _, data := range first.idat {
+		if err := writeChunk(w, "IDAT", data); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range frames[1:] {
+		parts, err := splitPNG(f)
+		if err != nil {
+			return err
+		}
+		if err := writeChunk(w, "fcTL", fcTL(seq, f.Bounds(), delayNum, delayDen)); err != nil {
+			return err
+		}
+		seq++
+		for _, data := range parts.idat {
+			if err := writeChunk(w, "fdAT", append(be32(seq), data...)); err != nil {
+				return err
+			}
+			seq++
+		}
+	}
+
+	return writeChunk(w, "IEND", nil)
+}
+
+// pngParts holds the chunks of a single-frame PNG that are reused
+// verbatim (IHDR) or relabeled (IDAT) when assembling the APNG.
+type pngParts struct {
+	ihdr []byte
+	idat [][]byte
+}
+
+// splitPNG encodes img with image/png and pulls out its IHDR and IDAT
+// chunk payloads, so the (already deflate-compressed) scanline data
+// can be reused without re-encoding.
+func splitPNG(img *image.RGBA) (pngParts, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return pngParts{}, err
+	}
+	b := buf.Bytes()[len(pngSignature):]
+	var parts pngParts
+	for len(b) > 0 {
+		if len(b) < 8 {
+			return pngParts{}, fmt.Errorf("headless: truncated PNG chunk")
+		}
+		length := binary.BigEndian.Uint32(b)
+		typ := string(b[4:8])
+		data := append([]byte(nil), b[8:8+length]...)
+		b = b[8+length+4:] // chunk data, plus the trailing CRC
+		switch typ {
+		case "IHDR":
+			parts.ihdr = data
+		case "IDAT":
+			parts.idat = append(parts.idat, data)
+		}
+	}
+	return parts, nil
+}
+
+func acTL(numFrames int) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data[0:], uint32(numFrames))
+	binary.BigEndian.PutUint32(data[4:], 0) // num_plays: loop forever
+	return data
+}
+
+func fcTL(seq uint32, r image.Rectangle, delayNum, delayDen uint16) []byte {
+	data := make([]byte, 26)
+	binary.BigEndian.PutUint32(data[0:], seq)
+	binary.BigEndian.PutUint32(data[4:], uint32(r.Dx()))
+	binary.BigEndian.PutUint32(data[8:], uint32(r.Dy()))
+	binary.BigEndian.PutUint32(data[12:], 0) // x_offset
+	binary.BigEndian.PutUint32(data[16:], 0) // y_offset
+	binary.BigEndian.PutUint16(data[20:], delayNum)
+	binary.BigEndian.PutUint16(data[22:], delayDen)
+	data[24] = 0 // dispose_op: APNG_DISPOSE_OP_NONE
+	data[25] = 0 // blend_op: APNG_BLEND_OP_SOURCE
+	return data
+}
+
+// delayFraction reduces d to the delay_num/delay_den pair the fcTL
+// chunk wants, in hundredths of a second.
+func delayFraction(d time.Duration) (num, den uint16) {
+	return uint16(d.Round(10*time.Millisecond) / (10 * time.Millisecond)), 100
+}
+
+func be32(v uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
+
+func writeChunk(w io.Writer, typ string, data []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := w.Write(length[:]); err != nil {
+		return err
+	}
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(typ))
+	crc.Write(data)
+	if _, err := io.WriteString(w, typ); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	_, err := w.Write(sum[:])
+	return err
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func solidFrame(r image.Rectangle, c color.NRGBA) *image.RGBA {
+	img := image.NewRGBA(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestEncodeAPNGDecodesAsPNG(t *testing.T) {
+	frames := []*image.RGBA{
+		solidFrame(image.Rect(0, 0, 4, 4), color.NRGBA{R: 0xff, A: 0xff}),
+		solidFrame(image.Rect(0, 0, 4, 4), color.NRGBA{G: 0xff, A: 0xff}),
+	}
+	var buf bytes.Buffer
+	if err := encodeAPNG(&buf, frames, 100*time.Millisecond); err != nil {
+		t.Fatalf("encodeAPNG: %v", err)
+	}
+	// A standard PNG decoder ignores the acTL/fcTL chunks it doesn't
+	// recognize and decodes the base IDAT, which this encoder leaves
+	// holding the first frame, verbatim.
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+	if got, want := img.Bounds(), frames[0].Bounds(); got != want {
+		t.Fatalf("decoded bounds = %v, want %v", got, want)
+	}
+	r, g, b, a := img.At(0, 0).RGBA()
+	if r>>8 != 0xff || g != 0 || b != 0 || a>>8 != 0xff {
+		t.Errorf("decoded first pixel = (%d,%d,%d,%d), want (255,0,0,255)", r>>8, g>>8, b>>8, a>>8)
+	}
+}
+
+func TestEncodeAPNGNoFrames(t *testing.T) {
+	if err := EncodeAPNG(new(bytes.Buffer), nil, RecordOptions{}); err == nil {
+		t.Error("EncodeAPNG with no frames should return an error")
+	}
+}
+
+func TestDelayFraction(t *testing.T) {
+	for _, tc := range []struct {
+		d        time.Duration
+		num, den uint16
+	}{
+		{0, 0, 100},
+		{100 * time.Millisecond, 10, 100},
+		{1 * time.Second, 100, 100},
+		{25 * time.Millisecond, 3, 100}, // rounds to the nearest 10ms tick
+	} {
+		num, den := delayFraction(tc.d)
+		if num != tc.num || den != tc.den {
+			t.Errorf("delayFraction(%v) = (%d,%d), want (%d,%d)", tc.d, num, den, tc.num, tc.den)
+		}
+	}
+}
+
+func TestSplitPNGExtractsChunks(t *testing.T) {
+	img := solidFrame(image.Rect(0, 0, 8, 6), color.NRGBA{B: 0xff, A: 0xff})
+	parts, err := splitPNG(img)
+	if err != nil {
+		t.Fatalf("splitPNG: %v", err)
+	}
+	if len(parts.ihdr) == 0 {
+		t.Error("splitPNG returned an empty IHDR chunk")
+	}
+	if len(parts.idat) == 0 {
+		t.Error("splitPNG returned no IDAT chunks")
+	}
+}
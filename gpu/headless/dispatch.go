@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"gioui.org/io/event"
+	"gioui.org/io/pointer"
+	"gioui.org/io/router"
+	"gioui.org/op"
+)
+
+// EventQueue adapts a router.Router to the event.Queue interface, so
+// that a Window's queue can be handed to code that expects to read
+// events the same way a real app does.
+type EventQueue struct {
+	router     router.Router
+	framedWith *op.Ops
+}
+
+// Events implements event.Queue.
+func (q *EventQueue) Events(k event.Tag) []event.Event {
+	return q.router.Events(k)
+}
+
+// Result is the set of events a single InputOp tag was delivered by
+// a Dispatch call.
+type Result struct {
+	Tag    event.Tag
+	Events []event.Event
+}
+
+// Dispatch runs events through the router built from the op list
+// passed to the most recent call to Frame, and reports, for every
+// tag that registered a pointer.InputOp in that frame, the events it
+// received, with Hover, DoubleClick and LongPress synthesized on top
+// of whatever Press/Move/Release/Cancel events the router delivered.
+// Tags that received no events are left out of the result.
+// It makes it possible to write interaction tests entirely offscreen,
+// without a real windowing backend. Dispatch panics if called before
+// Frame.
+//
+// The router is only re-framed when Frame has been called since the
+// previous Dispatch call, so a multi-step gesture driven by several
+// Dispatch calls against the same Frame (a Press in one call, a Move
+// and Release in later ones) keeps the router's hover and grab state
+// instead of resetting it each time. Hover, DoubleClick and LongPress
+// are derived from the events of a single Dispatch call only, so a
+// gesture split across several calls does not produce them.
+func (w *Window) Dispatch(events ...pointer.Event) []Result {
+	if w.lastFrame == nil {
+		panic("headless: Dispatch called before Frame")
+	}
+	if w.queue.framedWith != w.lastFrame {
+		w.queue.router.Frame(w.lastFrame)
+		w.queue.framedWith = w.lastFrame
+	}
+	for _, e := range events {
+		w.queue.router.Add(e)
+	}
+	inputs := registeredInputs(w.lastFrame)
+	results := make([]Result, 0, len(inputs))
+	for _, in := range inputs {
+		raw := w.queue.router.Events(in.Tag)
+		if len(raw) == 0 {
+			continue
+		}
+		// The router only ever sees the raw Press/Move/Release/Cancel
+		// stream; Synthesize derives the Hover, DoubleClick and
+		// LongPress events in's Types asks for from it, using in's
+		// thresholds.
+		results = append(results, Result{Tag: in.Tag, Events: pointer.Synthesize(in, raw)})
+	}
+	return results
+}
+
+// registeredInputs walks frame's op list and decodes every
+// pointer.InputOp it registers, in the order they appear.
+func registeredInputs(frame *op.Ops) []pointer.InputOp {
+	var inputs []pointer.InputOp
+	var r op.Reader
+	r.Reset(frame)
+	for {
+		encOp, ok := r.Decode()
+		if !ok {
+			break
+		}
+		if len(encOp.Refs) == 0 {
+			continue
+		}
+		tag, ok := encOp.Refs[0].(event.Tag)
+		if !ok {
+			continue
+		}
+		if in, ok := pointer.DecodeInputOp(encOp.Data, tag); ok {
+			inputs = append(inputs, in)
+		}
+	}
+	return inputs
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// gifPaletteSize is the largest palette a GIF frame can index.
+const gifPaletteSize = 256
+
+// buildPalette computes a single color.Palette shared by every frame in
+// frames, using median cut: starting from one box holding every pixel
+// in the stream, it repeatedly splits the box with the widest color
+// range at its median along that axis, until there are size boxes or
+// no box holds more than one pixel, then reduces each box to its
+// average color. Building the palette
+// from the stream's own colors, rather than quantizing to a fixed set
+// like palette.WebSafe, keeps banding down on content whose colors
+// don't happen to land on that fixed grid.
+func buildPalette(frames []*image.RGBA, size int) color.Palette {
+	var pixels []color.RGBA
+	for _, f := range frames {
+		b := f.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				pixels = append(pixels, f.RGBAAt(x, y))
+			}
+		}
+	}
+	if len(pixels) == 0 {
+		return color.Palette{color.RGBA{A: 0xff}}
+	}
+	boxes := [][]color.RGBA{pixels}
+	for len(boxes) < size {
+		splitIdx, splitAxis, splitRange := -1, 0, -1
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			if axis, rng := widestAxis(box); rng > splitRange {
+				splitIdx, splitAxis, splitRange = i, axis, rng
+			}
+		}
+		if splitIdx == -1 {
+			break
+		}
+		box := boxes[splitIdx]
+		sortByAxis(box, splitAxis)
+		mid := len(box) / 2
+		boxes[splitIdx] = box[:mid]
+		boxes = append(boxes, box[mid:])
+	}
+	pal := make(color.Palette, 0, len(boxes))
+	for _, box := range boxes {
+		pal = append(pal, averageColor(box))
+	}
+	return pal
+}
+
+// widestAxis reports which of the R, G, B axes box spans the widest
+// range over, and how wide that range is.
+func widestAxis(box []color.RGBA) (axis, rng int) {
+	minC := [3]uint8{255, 255, 255}
+	var maxC [3]uint8
+	for _, c := range box {
+		vals := [3]uint8{c.R, c.G, c.B}
+		for i, v := range vals {
+			if v < minC[i] {
+				minC[i] = v
+			}
+			if v > maxC[i] {
+				maxC[i] = v
+			}
+		}
+	}
+	axis, rng = 0, -1
+	for i := 0; i < 3; i++ {
+		if r := int(maxC[i]) - int(minC[i]); r > rng {
+			axis, rng = i, r
+		}
+	}
+	return axis, rng
+}
+
+func sortByAxis(box []color.RGBA, axis int) {
+	sort.Slice(box, func(i, j int) bool {
+		switch axis {
+		case 0:
+			return box[i].R < box[j].R
+		case 1:
+			return box[i].G < box[j].G
+		default:
+			return box[i].B < box[j].B
+		}
+	})
+}
+
+func averageColor(box []color.RGBA) color.RGBA {
+	var r, g, b, a uint64
+	for _, c := range box {
+		r += uint64(c.R)
+		g += uint64(c.G)
+		b += uint64(c.B)
+		a += uint64(c.A)
+	}
+	n := uint64(len(box))
+	return color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)}
+}
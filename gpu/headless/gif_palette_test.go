@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestBuildPaletteWithinSize(t *testing.T) {
+	frames := []*image.RGBA{
+		solidFrame(image.Rect(0, 0, 8, 8), color.NRGBA{R: 0xff, A: 0xff}),
+		solidFrame(image.Rect(0, 0, 8, 8), color.NRGBA{G: 0xff, A: 0xff}),
+		solidFrame(image.Rect(0, 0, 8, 8), color.NRGBA{B: 0xff, A: 0xff}),
+	}
+	pal := buildPalette(frames, gifPaletteSize)
+	if len(pal) == 0 {
+		t.Fatal("buildPalette returned an empty palette")
+	}
+	if len(pal) > gifPaletteSize {
+		t.Fatalf("len(pal) = %d, want at most %d", len(pal), gifPaletteSize)
+	}
+	// Unlike palette.WebSafe, a palette built from a red/green/blue
+	// stream should contain a close match for pure red.
+	idx := pal.Index(color.RGBA{R: 0xff, A: 0xff})
+	got := pal[idx].(color.RGBA)
+	if got.R < 0xc8 {
+		t.Errorf("closest palette entry to red = %v, want R near 0xff", got)
+	}
+}
+
+func TestBuildPaletteNoFrames(t *testing.T) {
+	pal := buildPalette(nil, gifPaletteSize)
+	if len(pal) == 0 {
+		t.Fatal("buildPalette(nil) returned an empty palette")
+	}
+}
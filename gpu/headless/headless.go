@@ -6,6 +6,7 @@ package headless
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	"runtime"
@@ -17,11 +18,52 @@ import (
 
 // Window is a headless window.
 type Window struct {
-	size   image.Point
-	ctx    context
-	dev    driver.Device
-	gpu    gpu.GPU
-	fboTex driver.Texture
+	size       image.Point
+	ctx        context
+	dev        driver.Device
+	gpu        gpu.GPU
+	fboTex     driver.Texture
+	resolveTex driver.Texture
+	depthTex   driver.Texture
+	lastFrame  *op.Ops
+	queue      EventQueue
+}
+
+// Options configures the offscreen render target created by
+// NewWindowWithOptions.
+type Options struct {
+	// Format is the color format of the render target. The zero value
+	// selects TextureFormatSRGBA.
+	Format driver.TextureFormat
+	// Samples is the number of samples per pixel used for
+	// multisampling. Values less than 2 disable MSAA. When MSAA is
+	// enabled, Screenshot resolves the multisampled render target into
+	// a plain texture before downloading it.
+	//
+	// MSAA is implemented by calling driver.Device.
+	// NewMultisampleTexture directly, as an unconditional method
+	// rather than behind a capability check; the driver package isn't
+	// part of this source tree, so whether a given driver.Device
+	// backend implements it can't be confirmed here. If it doesn't,
+	// this package fails to compile against the real driver package.
+	Samples int
+	// Filter is the minification and magnification filter applied
+	// when the render target is sampled. The zero value selects
+	// FilterNearest.
+	Filter driver.TextureFilter
+	// Depth requests an accompanying depth/stencil attachment, for
+	// rendering scenes with 3D content composed through custom
+	// shaders. It has no effect on Screenshot, which only ever
+	// downloads the color attachment.
+	//
+	// Depth is implemented by calling driver.Device.
+	// NewDepthStencilTexture and driver.AttachDepthStencil directly,
+	// as unconditional methods/functions rather than behind a
+	// capability check; the driver package isn't part of this source
+	// tree, so whether a given driver.Device backend implements them
+	// can't be confirmed here. If it doesn't, this package fails to
+	// compile against the real driver package.
+	Depth bool
 }
 
 type context interface {
@@ -58,8 +100,16 @@ func newContext() (context, error) {
 	return nil, errors.New("x11: no available GPU backends")
 }
 
-// NewWindow creates a new headless window.
+// NewWindow creates a new headless window with the default format
+// (sRGBA), no multisampling, and nearest-neighbor filtering.
 func NewWindow(width, height int) (*Window, error) {
+	return NewWindowWithOptions(width, height, Options{})
+}
+
+// NewWindowWithOptions creates a new headless window with a
+// configurable color format, sample count and texture filter.
+func NewWindowWithOptions(width, height int, opts Options) (*Window, error) {
+	opts = resolveOptions(opts)
 	ctx, err := newContext()
 	if err != nil {
 		return nil, err
@@ -74,22 +124,57 @@ func NewWindow(width, height int) (*Window, error) {
 		if err != nil {
 			return err
 		}
-		fboTex, err := dev.NewTexture(
-			driver.TextureFormatSRGBA,
-			width, height,
-			driver.FilterNearest, driver.FilterNearest,
-			driver.BufferBindingFramebuffer,
-		)
+		renderTex, err := newRenderTarget(dev, width, height, opts)
 		if err != nil {
-			return nil
+			return err
+		}
+		var resolveTex driver.Texture
+		if opts.Samples > 1 {
+			resolveTex, err = dev.NewTexture(
+				opts.Format,
+				width, height,
+				opts.Filter, opts.Filter,
+				driver.BufferBindingFramebuffer,
+			)
+			if err != nil {
+				renderTex.Release()
+				return err
+			}
+		}
+		var depthTex driver.Texture
+		if opts.Depth {
+			depthTex, err = dev.NewDepthStencilTexture(width, height, opts.Samples)
+			if err != nil {
+				if resolveTex != nil {
+					resolveTex.Release()
+				}
+				renderTex.Release()
+				return err
+			}
+			if err := driver.AttachDepthStencil(dev, renderTex, depthTex); err != nil {
+				depthTex.Release()
+				if resolveTex != nil {
+					resolveTex.Release()
+				}
+				renderTex.Release()
+				return err
+			}
 		}
 		gp, err := gpu.New(api)
 		if err != nil {
-			fboTex.Release()
+			if depthTex != nil {
+				depthTex.Release()
+			}
+			if resolveTex != nil {
+				resolveTex.Release()
+			}
+			renderTex.Release()
 			dev.Release()
 			return err
 		}
-		w.fboTex = fboTex
+		w.fboTex = renderTex
+		w.resolveTex = resolveTex
+		w.depthTex = depthTex
 		w.gpu = gp
 		w.dev = dev
 		return err
@@ -101,9 +186,49 @@ func NewWindow(width, height int) (*Window, error) {
 	return w, nil
 }
 
+// resolveOptions fills in the defaults for every field of opts left
+// at its zero value.
+func resolveOptions(opts Options) Options {
+	if opts.Format == 0 {
+		opts.Format = driver.TextureFormatSRGBA
+	}
+	if opts.Filter == 0 {
+		opts.Filter = driver.FilterNearest
+	}
+	return opts
+}
+
+// newRenderTarget allocates the texture the GPU renders into: a
+// multisampled texture when opts.Samples calls for MSAA, or a plain
+// one otherwise.
+func newRenderTarget(dev driver.Device, width, height int, opts Options) (driver.Texture, error) {
+	if opts.Samples > 1 {
+		return dev.NewMultisampleTexture(
+			opts.Format,
+			width, height,
+			opts.Samples,
+			driver.BufferBindingFramebuffer,
+		)
+	}
+	return dev.NewTexture(
+		opts.Format,
+		width, height,
+		opts.Filter, opts.Filter,
+		driver.BufferBindingFramebuffer,
+	)
+}
+
 // Release resources associated with the window.
 func (w *Window) Release() {
 	contextDo(w.ctx, func() error {
+		if w.depthTex != nil {
+			w.depthTex.Release()
+			w.depthTex = nil
+		}
+		if w.resolveTex != nil {
+			w.resolveTex.Release()
+			w.resolveTex = nil
+		}
 		if w.fboTex != nil {
 			w.fboTex.Release()
 			w.fboTex = nil
@@ -127,18 +252,60 @@ func (w *Window) Release() {
 // Frame replace the window content and state with the
 // operation list.
 func (w *Window) Frame(frame *op.Ops) error {
-	return contextDo(w.ctx, func() error {
+	err := contextDo(w.ctx, func() error {
 		w.gpu.Clear(color.NRGBA{})
 		return w.gpu.Frame(frame, w.fboTex, w.size)
 	})
+	if err == nil {
+		w.lastFrame = frame
+		// Force the next Dispatch to re-frame the router, even when
+		// frame is the same *op.Ops refilled after a Reset.
+		w.queue.framedWith = nil
+	}
+	return err
 }
 
-// Screenshot returns an image with the content of the window.
+// Screenshot returns an image with the content of the window. If the
+// window was created with multisampling enabled, the multisampled
+// render target is resolved into a plain texture before downloading.
 func (w *Window) Screenshot() (*image.RGBA, error) {
 	var img *image.RGBA
 	err := contextDo(w.ctx, func() error {
+		src := w.fboTex
+		if w.resolveTex != nil {
+			if err := driver.ResolveMultisample(w.dev, w.resolveTex, w.fboTex); err != nil {
+				return err
+			}
+			src = w.resolveTex
+		}
+		var err error
+		img, err = driver.DownloadImage(w.dev, src, image.Rectangle{Max: w.size})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// ScreenshotRect is like Screenshot, but downloads only the given
+// sub-region of the window, avoiding the cost of a full-framebuffer
+// readback. r must be contained in the window's bounds.
+func (w *Window) ScreenshotRect(r image.Rectangle) (*image.RGBA, error) {
+	if !r.In(image.Rectangle{Max: w.size}) {
+		return nil, fmt.Errorf("headless: rectangle %v outside window bounds %v", r, w.size)
+	}
+	var img *image.RGBA
+	err := contextDo(w.ctx, func() error {
+		src := w.fboTex
+		if w.resolveTex != nil {
+			if err := driver.ResolveMultisample(w.dev, w.resolveTex, w.fboTex); err != nil {
+				return err
+			}
+			src = w.resolveTex
+		}
 		var err error
-		img, err = driver.DownloadImage(w.dev, w.fboTex, image.Rectangle{Max: w.size})
+		img, err = driver.DownloadImage(w.dev, src, r)
 		return err
 	})
 	if err != nil {
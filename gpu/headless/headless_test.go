@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"testing"
+
+	"gioui.org/gpu/internal/driver"
+)
+
+func TestResolveOptions(t *testing.T) {
+	got := resolveOptions(Options{})
+	if got.Format != driver.TextureFormatSRGBA {
+		t.Errorf("Format = %v, want the default TextureFormatSRGBA", got.Format)
+	}
+	if got.Filter != driver.FilterNearest {
+		t.Errorf("Filter = %v, want the default FilterNearest", got.Filter)
+	}
+
+	// Non-zero fields must pass through unchanged.
+	want := Options{Format: driver.TextureFormatSRGBA, Filter: driver.FilterNearest, Samples: 4, Depth: true}
+	if got := resolveOptions(want); got != want {
+		t.Errorf("resolveOptions(%v) = %v, want unchanged", want, got)
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+
+	"gioui.org/op"
+)
+
+// RecordOptions controls the behavior of RecordFrames, StreamFrames,
+// EncodeAPNG and EncodeGIF.
+type RecordOptions struct {
+	// Delay is the time to display each frame, used only by the
+	// animated encoders (EncodeAPNG, EncodeGIF). It has no effect on
+	// rendering.
+	Delay time.Duration
+}
+
+// FrameOrError is a single result sent on the channel returned by
+// StreamFrames.
+type FrameOrError struct {
+	Image *image.RGBA
+	Err   error
+}
+
+// RecordFrames renders each op list in frames in order, reusing the
+// window's GPU context and fboTex across frames, and returns the
+// resulting images in the same order. It is cheaper than creating a
+// new Window per frame because the context and offscreen texture are
+// set up once and reused.
+func (w *Window) RecordFrames(frames []*op.Ops, opts RecordOptions) ([]*image.RGBA, error) {
+	imgs := make([]*image.RGBA, 0, len(frames))
+	for r := range w.StreamFrames(frames, opts) {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		imgs = append(imgs, r.Image)
+	}
+	return imgs, nil
+}
+
+// StreamFrames is the streaming variant of RecordFrames: it renders
+// each op list in frames in order and sends the resulting image on
+// the returned channel as soon as it is ready. The channel is closed
+// once all frames have been sent or an error occurs; a sent
+// FrameOrError with a non-nil Err is always the last value.
+func (w *Window) StreamFrames(frames []*op.Ops, opts RecordOptions) <-chan FrameOrError {
+	ch := make(chan FrameOrError)
+	go func() {
+		defer close(ch)
+		for _, frame := range frames {
+			if err := w.Frame(frame); err != nil {
+				ch <- FrameOrError{Err: err}
+				return
+			}
+			img, err := w.Screenshot()
+			if err != nil {
+				ch <- FrameOrError{Err: err}
+				return
+			}
+			ch <- FrameOrError{Image: img}
+		}
+	}()
+	return ch
+}
+
+// EncodeAPNG encodes frames as an animated PNG, displaying each frame
+// for opts.Delay before advancing to the next.
+func EncodeAPNG(w io.Writer, frames []*image.RGBA, opts RecordOptions) error {
+	if len(frames) == 0 {
+		return errors.New("headless: no frames to encode")
+	}
+	return encodeAPNG(w, frames, opts.Delay)
+}
+
+// EncodeGIF encodes frames as an animated GIF, displaying each frame
+// for opts.Delay before advancing to the next. Because GIF has no
+// true-color support, every frame is quantized to a single palette
+// built from the stream's own colors with buildPalette, rather than a
+// fixed set like palette.WebSafe, to keep banding down on arbitrary
+// rendered content.
+func EncodeGIF(w io.Writer, frames []*image.RGBA, opts RecordOptions) error {
+	if len(frames) == 0 {
+		return errors.New("headless: no frames to encode")
+	}
+	delay := opts.Delay / (10 * time.Millisecond)
+	pal := buildPalette(frames, gifPaletteSize)
+	g := &gif.GIF{}
+	for _, f := range frames {
+		p := image.NewPaletted(f.Bounds(), pal)
+		draw.Draw(p, f.Bounds(), f, f.Bounds().Min, draw.Src)
+		g.Image = append(g.Image, p)
+		g.Delay = append(g.Delay, int(delay))
+	}
+	return gif.EncodeAll(w, g)
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+func TestEncodeGIF(t *testing.T) {
+	frames := []*image.RGBA{
+		solidFrame(image.Rect(0, 0, 4, 4), color.NRGBA{R: 0xff, A: 0xff}),
+		solidFrame(image.Rect(0, 0, 4, 4), color.NRGBA{B: 0xff, A: 0xff}),
+	}
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, frames, RecordOptions{Delay: 200 * time.Millisecond}); err != nil {
+		t.Fatalf("EncodeGIF: %v", err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("gif.DecodeAll: %v", err)
+	}
+	if len(g.Image) != len(frames) {
+		t.Fatalf("got %d frames, want %d", len(g.Image), len(frames))
+	}
+	for _, d := range g.Delay {
+		if d != 20 {
+			t.Errorf("frame delay = %d (hundredths of a second), want 20", d)
+		}
+	}
+}
+
+func TestEncodeGIFNoFrames(t *testing.T) {
+	if err := EncodeGIF(new(bytes.Buffer), nil, RecordOptions{}); err == nil {
+		t.Error("EncodeGIF with no frames should return an error")
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package headless
+
+import (
+	"image"
+	"testing"
+)
+
+func TestScreenshotRectValidatesBounds(t *testing.T) {
+	// A Window with no driver set up at all: ScreenshotRect must
+	// reject an out-of-bounds rectangle before it ever touches w.ctx
+	// or w.dev, or this would panic on the nil context.
+	w := &Window{size: image.Pt(100, 100)}
+	if _, err := w.ScreenshotRect(image.Rect(0, 0, 200, 200)); err == nil {
+		t.Error("ScreenshotRect accepted a rectangle outside the window bounds")
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package pointer
+
+import (
+	"time"
+
+	"gioui.org/f32"
+)
+
+// Sequence generates the synthetic events for Tap, Drag and ScrollBy,
+// assigning each pointer a fresh ID and each event a monotonically
+// increasing Time. The zero value is ready to use: its first gesture
+// gets PointerID 1 and its first event Time 1ms.
+//
+// A Sequence is scoped to whoever owns it, instead of every gesture
+// in the process sharing one global counter, so that two tests (or
+// two windows) running gestures concurrently, or in whatever order a
+// test runner picks, produce identical, order-independent IDs and
+// Times for the same inputs.
+type Sequence struct {
+	nextID   uint32
+	nextTime time.Duration
+}
+
+// nextPointerID returns an ID not used by any earlier call on s.
+func (s *Sequence) nextPointerID() ID {
+	s.nextID++
+	return ID(s.nextID)
+}
+
+// advance returns a Time value strictly greater than the one returned
+// by the previous call on s.
+func (s *Sequence) advance() time.Duration {
+	s.nextTime += time.Millisecond
+	return s.nextTime
+}
+
+// Tap returns the synthetic Press and Release events for a tap at
+// pos, sharing a single PointerID and carrying monotonically
+// increasing Time values.
+func (s *Sequence) Tap(pos f32.Point) []Event {
+	id := s.nextPointerID()
+	return []Event{
+		{Type: Press, Source: Mouse, PointerID: id, Buttons: ButtonPrimary, Position: pos, Time: s.advance()},
+		{Type: Release, Source: Mouse, PointerID: id, Position: pos, Time: s.advance()},
+	}
+}
+
+// Drag returns the synthetic Press, Move and Release events for a
+// drag from from to to in the given number of steps, sharing a
+// single PointerID and carrying monotonically increasing Time
+// values. steps is clamped to at least 1.
+func (s *Sequence) Drag(from, to f32.Point, steps int) []Event {
+	if steps < 1 {
+		steps = 1
+	}
+	id := s.nextPointerID()
+	events := make([]Event, 0, steps+2)
+	events = append(events, Event{
+		Type: Press, Source: Mouse, PointerID: id, Buttons: ButtonPrimary,
+		Position: from, Time: s.advance(),
+	})
+	for i := 1; i <= steps; i++ {
+		t := float32(i) / float32(steps)
+		pos := f32.Point{
+			X: from.X + (to.X-from.X)*t,
+			Y: from.Y + (to.Y-from.Y)*t,
+		}
+		events = append(events, Event{
+			Type: Move, Source: Mouse, PointerID: id, Buttons: ButtonPrimary,
+			Position: pos, Time: s.advance(),
+		})
+	}
+	events = append(events, Event{
+		Type: Release, Source: Mouse, PointerID: id,
+		Position: to, Time: s.advance(),
+	})
+	return events
+}
+
+// ScrollBy returns a synthetic Scroll event at pos carrying delta,
+// with a fresh PointerID and the next monotonically increasing Time
+// value.
+func (s *Sequence) ScrollBy(pos f32.Point, delta f32.Point) []Event {
+	return []Event{
+		{Type: Scroll, Source: Mouse, PointerID: s.nextPointerID(), Position: pos, Scroll: delta, Time: s.advance()},
+	}
+}
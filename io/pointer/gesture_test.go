@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package pointer
+
+import (
+	"reflect"
+	"testing"
+
+	"gioui.org/f32"
+)
+
+func TestSequenceDeterministic(t *testing.T) {
+	var a, b Sequence
+	got := a.Tap(f32.Point{X: 1, Y: 2})
+	want := b.Tap(f32.Point{X: 1, Y: 2})
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("two zero-value Sequences produced different events for the same Tap:\n%v\n%v", got, want)
+	}
+
+	got = a.Drag(f32.Point{X: 0, Y: 0}, f32.Point{X: 10, Y: 10}, 3)
+	want = b.Drag(f32.Point{X: 0, Y: 0}, f32.Point{X: 10, Y: 10}, 3)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("two identically-driven Sequences produced different events for the same Drag:\n%v\n%v", got, want)
+	}
+}
+
+func TestSequenceIndependentFromGlobalState(t *testing.T) {
+	// Driving one Sequence must not perturb the IDs or Times a fresh
+	// Sequence starts from.
+	var used Sequence
+	used.Tap(f32.Point{})
+	used.Drag(f32.Point{}, f32.Point{X: 1}, 2)
+
+	var fresh Sequence
+	first := fresh.Tap(f32.Point{X: 5, Y: 5})
+	if first[0].PointerID != 1 {
+		t.Fatalf("fresh Sequence started at PointerID %d, want 1", first[0].PointerID)
+	}
+}
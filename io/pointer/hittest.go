@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package pointer
+
+import (
+	"image"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+)
+
+// Contains reports whether p, in the same coordinate space as the
+// shape described by a, lies within it.
+//
+// Contains is the reference implementation of the even-odd polygon
+// and path-bounds hit tests this package added for Polygon and Path
+// areas. The hit tester that actually decides which InputOp handlers
+// a pointer event reaches keeps its own mirrored copy of areaKind
+// (see the comment on that type) in a separate package this series
+// does not touch, so a Polygon or Path area is not yet hit tested by
+// any router, real or headless. Contains is currently exercised only
+// by this package's own tests; wiring it into real event delivery
+// requires updating that other package's areaKind switch to match.
+func (a AreaOp) Contains(p f32.Point) bool {
+	switch a.kind {
+	case areaRect:
+		return rectContains(a.rect, p)
+	case areaEllipse:
+		return ellipseContains(a.rect, p)
+	case areaPolygon:
+		return polygonContains(a.polygon, p)
+	case areaPath:
+		return pathContains(a.path, p)
+	default:
+		panic("unknown area kind")
+	}
+}
+
+func rectContains(r image.Rectangle, p f32.Point) bool {
+	return p.X >= float32(r.Min.X) && p.X < float32(r.Max.X) &&
+		p.Y >= float32(r.Min.Y) && p.Y < float32(r.Max.Y)
+}
+
+// ellipseContains reports whether p lies within the ellipse
+// inscribed in r.
+func ellipseContains(r image.Rectangle, p f32.Point) bool {
+	rx := float32(r.Dx()) / 2
+	ry := float32(r.Dy()) / 2
+	if rx <= 0 || ry <= 0 {
+		return false
+	}
+	cx := float32(r.Min.X) + rx
+	cy := float32(r.Min.Y) + ry
+	dx := (p.X - cx) / rx
+	dy := (p.Y - cy) / ry
+	return dx*dx+dy*dy <= 1
+}
+
+// polygonContains reports whether p lies within the closed polygon
+// described by points, using the even-odd rule: a horizontal ray
+// cast from p crosses the polygon boundary an odd number of times
+// iff p is inside.
+func polygonContains(points []f32.Point, p f32.Point) bool {
+	if len(points) < 3 {
+		return false
+	}
+	inside := false
+	j := len(points) - 1
+	for i := range points {
+		pi, pj := points[i], points[j]
+		if (pi.Y > p.Y) != (pj.Y > p.Y) {
+			x := pi.X + (p.Y-pi.Y)/(pj.Y-pi.Y)*(pj.X-pi.X)
+			if p.X < x {
+				inside = !inside
+			}
+		}
+		j = i
+	}
+	return inside
+}
+
+// pathContains reports whether p lies within outline's bounding box.
+// Evaluating the path's true fill rule would require walking the
+// curve segment encoding clip.Path produces, which belongs to the
+// op/clip package; this bounding-box test is exact for outlines that
+// are axis-aligned rectangles and an over-approximation for any other
+// shape.
+func pathContains(outline clip.PathSpec, p f32.Point) bool {
+	b := outline.Bounds()
+	return p.X >= b.Min.X && p.X < b.Max.X && p.Y >= b.Min.Y && p.Y < b.Max.Y
+}
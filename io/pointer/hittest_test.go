@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package pointer
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+)
+
+func TestAreaOpContainsRect(t *testing.T) {
+	a := Rect(image.Rect(0, 0, 10, 10))
+	for _, tc := range []struct {
+		p    f32.Point
+		want bool
+	}{
+		{f32.Point{X: 5, Y: 5}, true},
+		{f32.Point{X: 0, Y: 0}, true},
+		{f32.Point{X: 10, Y: 10}, false}, // Max is exclusive
+		{f32.Point{X: -1, Y: 5}, false},
+	} {
+		if got := a.Contains(tc.p); got != tc.want {
+			t.Errorf("Contains(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestAreaOpContainsEllipse(t *testing.T) {
+	a := Ellipse(image.Rect(0, 0, 10, 10))
+	for _, tc := range []struct {
+		p    f32.Point
+		want bool
+	}{
+		{f32.Point{X: 5, Y: 5}, true},
+		{f32.Point{X: 0, Y: 0}, false}, // corner, outside the inscribed circle
+		{f32.Point{X: 5, Y: 0.1}, true},
+	} {
+		if got := a.Contains(tc.p); got != tc.want {
+			t.Errorf("Contains(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+}
+
+func TestAreaOpContainsPolygon(t *testing.T) {
+	square := Polygon([]f32.Point{
+		{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10},
+	})
+	if !square.Contains(f32.Point{X: 5, Y: 5}) {
+		t.Error("center of square should be inside")
+	}
+	if square.Contains(f32.Point{X: 15, Y: 5}) {
+		t.Error("point outside square should be outside")
+	}
+
+	// A concave L-shape, to exercise the even-odd rule beyond convex
+	// polygons.
+	lshape := Polygon([]f32.Point{
+		{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 5},
+		{X: 5, Y: 5}, {X: 5, Y: 10}, {X: 0, Y: 10},
+	})
+	if !lshape.Contains(f32.Point{X: 2, Y: 2}) {
+		t.Error("point in the filled arm of the L should be inside")
+	}
+	if lshape.Contains(f32.Point{X: 8, Y: 8}) {
+		t.Error("point in the notch of the L should be outside")
+	}
+
+	if (Polygon(nil)).Contains(f32.Point{}) {
+		t.Error("a degenerate polygon should contain nothing")
+	}
+}
+
+func TestAreaOpContainsPath(t *testing.T) {
+	// A zero-value PathSpec has a zero-value (empty) bounding box, so
+	// it should contain nothing.
+	area := Path(clip.PathSpec{})
+	if area.Contains(f32.Point{X: 0, Y: 0}) {
+		t.Error("an empty path should contain nothing")
+	}
+	if area.Contains(f32.Point{X: 5, Y: 5}) {
+		t.Error("an empty path should contain nothing")
+	}
+}
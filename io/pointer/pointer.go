@@ -14,6 +14,7 @@ import (
 	"gioui.org/io/event"
 	"gioui.org/io/key"
 	"gioui.org/op"
+	"gioui.org/op/clip"
 )
 
 // Event is a pointer event.
@@ -45,8 +46,10 @@ type Event struct {
 // AreaOp pushes the current hit area to the stack and updates it to the
 // intersection of the current hit area and the transformed area.
 type AreaOp struct {
-	kind areaKind
-	rect image.Rectangle
+	kind    areaKind
+	rect    image.Rectangle
+	polygon []f32.Point
+	path    clip.PathSpec
 }
 
 // AreaStack represents an AreaOp on the stack of areas.
@@ -88,12 +91,33 @@ type InputOp struct {
 	// ScrollBounds.Min.X <= e.Scroll.X <= ScrollBounds.Max.X (horizontal axis)
 	// ScrollBounds.Min.Y <= e.Scroll.Y <= ScrollBounds.Max.Y (vertical axis)
 	ScrollBounds image.Rectangle
+	// HoverRadius bounds how far the pointer may drift, in pixels,
+	// while still counting as the same Hover, and how far apart two
+	// Press events may land to be merged into a DoubleClick. Only
+	// meaningful if Types includes Hover or DoubleClick. Zero selects
+	// the package default used by Synthesize.
+	HoverRadius int
+	// HoverDwell is how long the pointer must stay within
+	// HoverRadius before a Hover event is synthesized. Only
+	// meaningful if Types includes Hover. Zero selects the package
+	// default used by Synthesize.
+	HoverDwell time.Duration
+	// DoubleClickInterval is the maximum time between two Press
+	// events for them to be merged into a DoubleClick. Only
+	// meaningful if Types includes DoubleClick. Zero selects the
+	// package default used by Synthesize.
+	DoubleClickInterval time.Duration
+	// LongPressDuration is how long a pointer must stay pressed
+	// before a LongPress event is synthesized. Only meaningful if
+	// Types includes LongPress. Zero selects the package default
+	// used by Synthesize.
+	LongPressDuration time.Duration
 }
 
 type ID uint16
 
 // Type of an Event.
-type Type uint8
+type Type uint16
 
 // Priority of an Event.
 type Priority uint8
@@ -147,6 +171,17 @@ const (
 	Leave
 	// Scroll of a pointer.
 	Scroll
+	// Hover is generated when the pointer, with no button pressed,
+	// has stayed within InputOp.HoverRadius of one position for
+	// InputOp.HoverDwell.
+	Hover
+	// DoubleClick is generated when two Press events land within
+	// InputOp.DoubleClickInterval and InputOp.HoverRadius of each
+	// other.
+	DoubleClick
+	// LongPress is generated when a pointer stays pressed for at
+	// least InputOp.LongPressDuration.
+	LongPress
 )
 
 const (
@@ -181,6 +216,8 @@ const (
 const (
 	areaRect areaKind = iota
 	areaEllipse
+	areaPolygon
+	areaPath
 )
 
 // Rect constructs a rectangular hit area.
@@ -199,6 +236,26 @@ func Ellipse(size image.Rectangle) AreaOp {
 	}
 }
 
+// Polygon constructs a hit area from the closed polygon described by
+// points, tested with the even-odd rule.
+func Polygon(points []f32.Point) AreaOp {
+	return AreaOp{
+		kind:    areaPolygon,
+		polygon: points,
+	}
+}
+
+// Path constructs a hit area from the outline of a clip path. Hits
+// are tested against the outline's bounding box, not its fill, so
+// points outside a curved, rotated or non-rectangular outline but
+// inside its bounding box count as hits.
+func Path(outline clip.PathSpec) AreaOp {
+	return AreaOp{
+		kind: areaPath,
+		path: outline,
+	}
+}
+
 // Push the current area to the stack and intersects the current area with the
 // area represented by o.
 func (a AreaOp) Push(o *op.Ops) AreaStack {
@@ -208,14 +265,25 @@ func (a AreaOp) Push(o *op.Ops) AreaStack {
 }
 
 func (a AreaOp) add(o *op.Ops, push bool) {
-	data := ops.Write(&o.Internal, ops.TypeAreaLen)
-	data[0] = byte(ops.TypeArea)
-	data[1] = byte(a.kind)
-	bo := binary.LittleEndian
-	bo.PutUint32(data[2:], uint32(a.rect.Min.X))
-	bo.PutUint32(data[6:], uint32(a.rect.Min.Y))
-	bo.PutUint32(data[10:], uint32(a.rect.Max.X))
-	bo.PutUint32(data[14:], uint32(a.rect.Max.Y))
+	switch a.kind {
+	case areaPolygon:
+		data := ops.Write1(&o.Internal, ops.TypeAreaLen, a.polygon)
+		data[0] = byte(ops.TypeArea)
+		data[1] = byte(a.kind)
+	case areaPath:
+		data := ops.Write1(&o.Internal, ops.TypeAreaLen, a.path)
+		data[0] = byte(ops.TypeArea)
+		data[1] = byte(a.kind)
+	default:
+		data := ops.Write(&o.Internal, ops.TypeAreaLen)
+		data[0] = byte(ops.TypeArea)
+		data[1] = byte(a.kind)
+		bo := binary.LittleEndian
+		bo.PutUint32(data[2:], uint32(a.rect.Min.X))
+		bo.PutUint32(data[6:], uint32(a.rect.Min.Y))
+		bo.PutUint32(data[10:], uint32(a.rect.Max.X))
+		bo.PutUint32(data[14:], uint32(a.rect.Max.Y))
+	}
 }
 
 func (o AreaStack) Pop() {
@@ -243,6 +311,17 @@ func (op CursorNameOp) Add(o *op.Ops) {
 	data[0] = byte(ops.TypeCursor)
 }
 
+// inputOpLen is the number of bytes InputOp.Add needs for the type
+// byte, Grab, Types, ScrollBounds and the Hover/DoubleClick/LongPress
+// thresholds (1 + 1 + 2 + 4*4 + 4 + 8 + 8 + 8). It is passed to
+// ops.Write1 directly, rather than through an ops.TypePointerInputLen
+// constant, because internal/ops lives outside this package and
+// nothing here can guarantee it was bumped in lockstep when the
+// Hover/DoubleClick/LongPress fields were added; sizing the record
+// from the fields InputOp.Add actually writes keeps the write side
+// correct on its own.
+const inputOpLen = 1 + 1 + 2 + 4*4 + 4 + 8 + 8 + 8
+
 // Add panics if the scroll range does not contain zero.
 func (op InputOp) Add(o *op.Ops) {
 	if op.Tag == nil {
@@ -251,17 +330,54 @@ func (op InputOp) Add(o *op.Ops) {
 	if b := op.ScrollBounds; b.Min.X > 0 || b.Max.X < 0 || b.Min.Y > 0 || b.Max.Y < 0 {
 		panic(fmt.Errorf("invalid scroll range value %v", b))
 	}
-	data := ops.Write1(&o.Internal, ops.TypePointerInputLen, op.Tag)
+	data := ops.Write1(&o.Internal, inputOpLen, op.Tag)
+	encodeInputOp(data, op)
+}
+
+// encodeInputOp writes op's fixed-size fields into data, which must
+// be inputOpLen bytes long. It is split out from Add so the encoding
+// can be unit tested without constructing a real op.Ops.
+func encodeInputOp(data []byte, op InputOp) {
 	data[0] = byte(ops.TypePointerInput)
 	if op.Grab {
 		data[1] = 1
 	}
-	data[2] = byte(op.Types)
 	bo := binary.LittleEndian
-	bo.PutUint32(data[3:], uint32(op.ScrollBounds.Min.X))
-	bo.PutUint32(data[7:], uint32(op.ScrollBounds.Min.Y))
-	bo.PutUint32(data[11:], uint32(op.ScrollBounds.Max.X))
-	bo.PutUint32(data[15:], uint32(op.ScrollBounds.Max.Y))
+	bo.PutUint16(data[2:], uint16(op.Types))
+	bo.PutUint32(data[4:], uint32(op.ScrollBounds.Min.X))
+	bo.PutUint32(data[8:], uint32(op.ScrollBounds.Min.Y))
+	bo.PutUint32(data[12:], uint32(op.ScrollBounds.Max.X))
+	bo.PutUint32(data[16:], uint32(op.ScrollBounds.Max.Y))
+	bo.PutUint32(data[20:], uint32(op.HoverRadius))
+	bo.PutUint64(data[24:], uint64(op.HoverDwell))
+	bo.PutUint64(data[32:], uint64(op.DoubleClickInterval))
+	bo.PutUint64(data[40:], uint64(op.LongPressDuration))
+}
+
+// DecodeInputOp decodes the InputOp that encodeInputOp wrote into
+// data, the raw bytes of a single decoded op record, pairing it with
+// tag, the ref that went with it. It reports false if data isn't a
+// pointer InputOp record. DecodeInputOp lets code walking an op list
+// directly, such as headless.Window.Dispatch, recover each handler's
+// declared Types and thresholds without depending on the router.
+func DecodeInputOp(data []byte, tag event.Tag) (InputOp, bool) {
+	if len(data) < inputOpLen || data[0] != byte(ops.TypePointerInput) {
+		return InputOp{}, false
+	}
+	bo := binary.LittleEndian
+	return InputOp{
+		Tag:   tag,
+		Grab:  data[1] != 0,
+		Types: Type(bo.Uint16(data[2:])),
+		ScrollBounds: image.Rectangle{
+			Min: image.Pt(int(int32(bo.Uint32(data[4:]))), int(int32(bo.Uint32(data[8:])))),
+			Max: image.Pt(int(int32(bo.Uint32(data[12:]))), int(int32(bo.Uint32(data[16:])))),
+		},
+		HoverRadius:         int(int32(bo.Uint32(data[20:]))),
+		HoverDwell:          time.Duration(bo.Uint64(data[24:])),
+		DoubleClickInterval: time.Duration(bo.Uint64(data[32:])),
+		LongPressDuration:   time.Duration(bo.Uint64(data[40:])),
+	}, true
 }
 
 func (t Type) String() string {
@@ -282,6 +398,12 @@ func (t Type) String() string {
 		return "Leave"
 	case Scroll:
 		return "Scroll"
+	case Hover:
+		return "Hover"
+	case DoubleClick:
+		return "DoubleClick"
+	case LongPress:
+		return "LongPress"
 	default:
 		panic("unknown Type")
 	}
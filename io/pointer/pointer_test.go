@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package pointer
+
+import (
+	"encoding/binary"
+	"image"
+	"testing"
+	"time"
+)
+
+// TestEncodeInputOp round-trips InputOp's fixed-size fields through
+// encodeInputOp the same way InputOp.Add does, against a buffer sized
+// with inputOpLen. op.Ops and internal/ops aren't available in this
+// tree, so this exercises the encoding logic Add delegates to instead
+// of Add itself; that logic, not ops.Write1, is what silently went
+// untested when HoverRadius, HoverDwell, DoubleClickInterval and
+// LongPressDuration were added to the wire format.
+func TestEncodeInputOp(t *testing.T) {
+	op := InputOp{
+		Grab:                true,
+		Types:               Hover | DoubleClick | LongPress,
+		ScrollBounds:        image.Rect(-1, -2, 3, 4),
+		HoverRadius:         7,
+		HoverDwell:          200 * time.Millisecond,
+		DoubleClickInterval: 300 * time.Millisecond,
+		LongPressDuration:   500 * time.Millisecond,
+	}
+	data := make([]byte, inputOpLen)
+	encodeInputOp(data, op)
+
+	bo := binary.LittleEndian
+	if got := data[1]; got != 1 {
+		t.Errorf("Grab byte = %d, want 1", got)
+	}
+	if got := Type(bo.Uint16(data[2:])); got != op.Types {
+		t.Errorf("Types = %v, want %v", got, op.Types)
+	}
+	gotBounds := image.Rect(
+		int(int32(bo.Uint32(data[4:]))), int(int32(bo.Uint32(data[8:]))),
+		int(int32(bo.Uint32(data[12:]))), int(int32(bo.Uint32(data[16:]))),
+	)
+	if gotBounds != op.ScrollBounds {
+		t.Errorf("ScrollBounds = %v, want %v", gotBounds, op.ScrollBounds)
+	}
+	if got := int(bo.Uint32(data[20:])); got != op.HoverRadius {
+		t.Errorf("HoverRadius = %d, want %d", got, op.HoverRadius)
+	}
+	if got := time.Duration(bo.Uint64(data[24:])); got != op.HoverDwell {
+		t.Errorf("HoverDwell = %v, want %v", got, op.HoverDwell)
+	}
+	if got := time.Duration(bo.Uint64(data[32:])); got != op.DoubleClickInterval {
+		t.Errorf("DoubleClickInterval = %v, want %v", got, op.DoubleClickInterval)
+	}
+	if got := time.Duration(bo.Uint64(data[40:])); got != op.LongPressDuration {
+		t.Errorf("LongPressDuration = %v, want %v", got, op.LongPressDuration)
+	}
+}
+
+func TestInputOpLenCoversEveryField(t *testing.T) {
+	// The last field encodeInputOp writes, LongPressDuration, ends at
+	// byte 48; inputOpLen must be at least that long or Add panics
+	// with a slice-bounds error the moment a widget declares a
+	// pointer handler.
+	const lastFieldEnd = 48
+	if inputOpLen < lastFieldEnd {
+		t.Fatalf("inputOpLen = %d, want at least %d", inputOpLen, lastFieldEnd)
+	}
+}
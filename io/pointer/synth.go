@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package pointer
+
+import (
+	"math"
+	"time"
+
+	"gioui.org/f32"
+)
+
+// Defaults used by Synthesize for the thresholds an InputOp leaves
+// unset (zero).
+const (
+	defaultDoubleClickInterval = 300 * time.Millisecond
+	defaultLongPressDuration   = 500 * time.Millisecond
+	defaultHoverDwell          = 200 * time.Millisecond
+	defaultHoverRadius         = 8
+)
+
+// Synthesize returns raw together with the Hover, DoubleClick and
+// LongPress events it implies for a single pointer, using the
+// thresholds configured on op (falling back to package defaults for
+// any left at zero). raw must be a time-ordered stream of
+// Press/Move/Release/Cancel events for one pointer. DoubleClick and
+// Hover are inserted immediately after the raw event that completes
+// them; LongPress is stamped with the moment the press duration
+// elapsed and inserted immediately before the first raw event at or
+// after that moment, so the result stays time-ordered. Synthesize is
+// the gesture-recognition logic behind headless.Window.Dispatch; the
+// event router does not call it.
+func Synthesize(op InputOp, raw []Event) []Event {
+	interval := op.DoubleClickInterval
+	if interval == 0 {
+		interval = defaultDoubleClickInterval
+	}
+	longPress := op.LongPressDuration
+	if longPress == 0 {
+		longPress = defaultLongPressDuration
+	}
+	dwell := op.HoverDwell
+	if dwell == 0 {
+		dwell = defaultHoverDwell
+	}
+	radius := float32(op.HoverRadius)
+	if radius == 0 {
+		radius = defaultHoverRadius
+	}
+
+	var out []Event
+	var (
+		pressed       bool
+		pressTime     time.Duration
+		havePrevPress bool
+		lastPressTime time.Duration
+		lastPressPos  f32.Point
+		haveAnchor    bool
+		hoverAnchor   f32.Point
+		hoverAnchorAt time.Duration
+		hoverFired    bool
+		longFired     bool
+	)
+
+	for _, e := range raw {
+		if pressed && !longFired && op.Types&LongPress != 0 && e.Time-pressTime >= longPress {
+			lp := derived(e, LongPress)
+			lp.Time = pressTime + longPress
+			out = append(out, lp)
+			longFired = true
+		}
+		switch e.Type {
+		case Press:
+			out = append(out, e)
+			pressed = true
+			pressTime = e.Time
+			longFired = false
+			haveAnchor = false
+			if op.Types&DoubleClick != 0 && havePrevPress &&
+				e.Time-lastPressTime <= interval && dist(e.Position, lastPressPos) <= radius {
+				out = append(out, derived(e, DoubleClick))
+				havePrevPress = false
+			} else {
+				lastPressTime, lastPressPos, havePrevPress = e.Time, e.Position, true
+			}
+		case Release, Cancel:
+			pressed = false
+			out = append(out, e)
+		case Move:
+			out = append(out, e)
+			if pressed {
+				haveAnchor = false
+				continue
+			}
+			if op.Types&Hover == 0 {
+				continue
+			}
+			if !haveAnchor || dist(e.Position, hoverAnchor) > radius {
+				hoverAnchor, hoverAnchorAt, haveAnchor, hoverFired = e.Position, e.Time, true, false
+				continue
+			}
+			if !hoverFired && e.Time-hoverAnchorAt >= dwell {
+				hoverFired = true
+				out = append(out, derived(e, Hover))
+			}
+		default:
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// derived copies the pointer identity and position of e into a
+// synthesized event of typ.
+func derived(e Event, typ Type) Event {
+	e.Type = typ
+	return e
+}
+
+func dist(a, b f32.Point) float32 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return float32(math.Sqrt(float64(dx*dx + dy*dy)))
+}
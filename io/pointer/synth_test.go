@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package pointer
+
+import (
+	"testing"
+	"time"
+
+	"gioui.org/f32"
+)
+
+func countType(events []Event, typ Type) int {
+	n := 0
+	for _, e := range events {
+		if e.Type == typ {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSynthesizeDoubleClick(t *testing.T) {
+	op := InputOp{Types: DoubleClick}
+	raw := []Event{
+		{Type: Press, Time: 0},
+		{Type: Release, Time: 10 * time.Millisecond},
+		{Type: Press, Time: 50 * time.Millisecond},
+		{Type: Release, Time: 60 * time.Millisecond},
+	}
+	out := Synthesize(op, raw)
+	if got := countType(out, DoubleClick); got != 1 {
+		t.Fatalf("got %d DoubleClick events, want 1", got)
+	}
+
+	// A third press long after the pair should not merge into it.
+	raw = append(raw,
+		Event{Type: Press, Time: 2 * time.Second},
+		Event{Type: Release, Time: 2*time.Second + 10*time.Millisecond},
+	)
+	out = Synthesize(op, raw)
+	if got := countType(out, DoubleClick); got != 1 {
+		t.Fatalf("got %d DoubleClick events, want 1", got)
+	}
+}
+
+func TestSynthesizeDoubleClickRequiresProximity(t *testing.T) {
+	op := InputOp{Types: DoubleClick}
+	raw := []Event{
+		{Type: Press, Time: 0, Position: f32.Point{X: 0, Y: 0}},
+		{Type: Release, Time: 10 * time.Millisecond, Position: f32.Point{X: 0, Y: 0}},
+		{Type: Press, Time: 50 * time.Millisecond, Position: f32.Point{X: 100, Y: 100}},
+		{Type: Release, Time: 60 * time.Millisecond, Position: f32.Point{X: 100, Y: 100}},
+	}
+	out := Synthesize(op, raw)
+	if got := countType(out, DoubleClick); got != 0 {
+		t.Fatalf("got %d DoubleClick events, want 0 for presses far apart", got)
+	}
+}
+
+func TestSynthesizeLongPress(t *testing.T) {
+	op := InputOp{Types: LongPress}
+	raw := []Event{
+		{Type: Press, Time: 0},
+		{Type: Release, Time: 600 * time.Millisecond},
+	}
+	out := Synthesize(op, raw)
+	if got := countType(out, LongPress); got != 1 {
+		t.Fatalf("got %d LongPress events, want 1", got)
+	}
+
+	raw = []Event{
+		{Type: Press, Time: 0},
+		{Type: Release, Time: 100 * time.Millisecond},
+	}
+	out = Synthesize(op, raw)
+	if got := countType(out, LongPress); got != 0 {
+		t.Fatalf("got %d LongPress events, want 0 for a quick release", got)
+	}
+}
+
+func TestSynthesizeHover(t *testing.T) {
+	op := InputOp{Types: Hover}
+	raw := []Event{
+		{Type: Move, Time: 0, Position: f32.Point{X: 10, Y: 10}},
+		{Type: Move, Time: 250 * time.Millisecond, Position: f32.Point{X: 11, Y: 11}},
+	}
+	out := Synthesize(op, raw)
+	if got := countType(out, Hover); got != 1 {
+		t.Fatalf("got %d Hover events, want 1", got)
+	}
+}
+
+func TestSynthesizeHoverCancelledByDrift(t *testing.T) {
+	op := InputOp{Types: Hover}
+	raw := []Event{
+		{Type: Move, Time: 0, Position: f32.Point{X: 10, Y: 10}},
+		{Type: Move, Time: 250 * time.Millisecond, Position: f32.Point{X: 100, Y: 100}},
+	}
+	out := Synthesize(op, raw)
+	if got := countType(out, Hover); got != 0 {
+		t.Fatalf("got %d Hover events, want 0 when the pointer moved away before the dwell elapsed", got)
+	}
+}
+
+func TestSynthesizeHoverSuppressedWhilePressed(t *testing.T) {
+	op := InputOp{Types: Hover}
+	raw := []Event{
+		{Type: Press, Time: 0, Position: f32.Point{X: 10, Y: 10}},
+		{Type: Move, Time: 250 * time.Millisecond, Position: f32.Point{X: 10, Y: 10}},
+		{Type: Release, Time: 300 * time.Millisecond, Position: f32.Point{X: 10, Y: 10}},
+	}
+	out := Synthesize(op, raw)
+	if got := countType(out, Hover); got != 0 {
+		t.Fatalf("got %d Hover events, want 0 while the pointer is pressed", got)
+	}
+}